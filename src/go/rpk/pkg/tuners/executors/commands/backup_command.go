@@ -0,0 +1,82 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// backupCmd copies path to a sibling ".bak" file before it gets mutated by
+// a later command, so operators have something to restore from.
+type backupCmd struct {
+	fs   afero.Fs
+	path string
+}
+
+// NewBackupCmd creates a Command that backs up path to path+".bak".
+func NewBackupCmd(fs afero.Fs, path string) Command {
+	return &backupCmd{fs: fs, path: path}
+}
+
+func (c *backupCmd) backupPath() string {
+	return c.path + ".bak"
+}
+
+func (c *backupCmd) Execute() error {
+	content, err := afero.ReadFile(c.fs, c.path)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(c.fs, c.backupPath(), content, 0o644)
+}
+
+// RenderRollback removes the backup this command created, since the backup
+// itself isn't a mutation that needs undoing on the original file.
+func (c *backupCmd) RenderRollback(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "rm -f %s\n", c.backupPath())
+	return err
+}
+
+// RenderIdempotent refuses to overwrite an existing backup, since doing so
+// would destroy the original pre-tune state it's meant to preserve.
+func (c *backupCmd) RenderIdempotent(w io.Writer) error {
+	backup := c.backupPath()
+	_, err := fmt.Fprintf(w,
+		`if [ -e %s ]; then
+  echo "backup %s already exists, refusing to overwrite" >&2
+else
+  cp %s %s
+fi
+`, backup, backup, c.path, backup)
+	return err
+}
+
+func (c *backupCmd) RenderScript(format ScriptFormat, w io.Writer) error {
+	backup := c.backupPath()
+	switch format {
+	case Bash:
+		_, err := fmt.Fprintf(w, "cp %s %s\n", c.path, backup)
+		return err
+	case AnsiblePlaybook:
+		_, err := fmt.Fprintf(w, "    - name: back up %s\n      ansible.builtin.copy:\n        remote_src: true\n        src: %s\n        dest: %s\n\n", c.path, c.path, backup)
+		return err
+	case SystemdDropIn:
+		_, err := fmt.Fprintf(w, "# back up %s to %s before applying this unit\n", c.path, backup)
+		return err
+	case PowerShell:
+		_, err := fmt.Fprintf(w, "Copy-Item -Path '%s' -Destination '%s'\n", c.path, backup)
+		return err
+	default:
+		return fmt.Errorf("unknown script format '%s'", format)
+	}
+}
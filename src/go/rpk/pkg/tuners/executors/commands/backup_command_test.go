@@ -0,0 +1,34 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupCmdRenderIdempotentRefusesToOverwrite(t *testing.T) {
+	cmd := NewBackupCmd(afero.NewMemMapFs(), "/etc/conf")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderIdempotent(&buf))
+	require.Contains(t, buf.String(), "if [ -e /etc/conf.bak ]; then")
+	require.Contains(t, buf.String(), "refusing to overwrite")
+	require.Contains(t, buf.String(), "cp /etc/conf /etc/conf.bak")
+}
+
+func TestBackupCmdRenderRollback(t *testing.T) {
+	cmd := NewBackupCmd(afero.NewMemMapFs(), "/etc/conf")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderRollback(&buf))
+	require.Equal(t, "rm -f /etc/conf.bak\n", buf.String())
+}
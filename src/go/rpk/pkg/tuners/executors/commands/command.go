@@ -0,0 +1,80 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"fmt"
+	"io"
+)
+
+// ScriptFormat selects the syntax that Command.RenderScript emits, so a
+// single set of commands can drive either an ad hoc bash script or an
+// artifact meant for a configuration-management tool.
+type ScriptFormat int
+
+const (
+	// Bash renders a POSIX shell script, the historical rpk default.
+	Bash ScriptFormat = iota
+	// AnsiblePlaybook renders a list of Ansible tasks.
+	AnsiblePlaybook
+	// SystemdDropIn renders a systemd unit drop-in / tmpfiles.d snippet.
+	SystemdDropIn
+	// PowerShell renders a Windows PowerShell script.
+	PowerShell
+)
+
+func (f ScriptFormat) String() string {
+	switch f {
+	case Bash:
+		return "bash"
+	case AnsiblePlaybook:
+		return "ansible"
+	case SystemdDropIn:
+		return "systemd"
+	case PowerShell:
+		return "powershell"
+	default:
+		return fmt.Sprintf("ScriptFormat(%d)", int(f))
+	}
+}
+
+// ParseScriptFormat maps the --script-format flag value to a ScriptFormat.
+func ParseScriptFormat(s string) (ScriptFormat, error) {
+	switch s {
+	case "bash", "":
+		return Bash, nil
+	case "ansible":
+		return AnsiblePlaybook, nil
+	case "systemd":
+		return SystemdDropIn, nil
+	case "powershell":
+		return PowerShell, nil
+	default:
+		return Bash, fmt.Errorf("unknown script format '%s'", s)
+	}
+}
+
+// Command is a single tuning step. It can either be run directly against
+// the host (Execute) or rendered as a step of a generated script in one of
+// the supported ScriptFormats (RenderScript).
+type Command interface {
+	// Execute runs the command against the current host.
+	Execute() error
+	// RenderScript writes this command as a step of a script in the given
+	// format to w.
+	RenderScript(format ScriptFormat, w io.Writer) error
+	// RenderRollback writes, in Bash, the step that undoes this command,
+	// reading whatever prior state it needs at render time.
+	RenderRollback(w io.Writer) error
+	// RenderIdempotent writes, in Bash, this command wrapped in a guard
+	// that checks the current state and skips the mutation if it already
+	// matches the desired one, so the step is safe to re-run.
+	RenderIdempotent(w io.Writer) error
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// writeFileCmd overwrites the contents of a single file, e.g. a CPU
+// governor or IRQ affinity pseudo-file under /sys.
+type writeFileCmd struct {
+	fs      afero.Fs
+	path    string
+	content string
+}
+
+// NewWriteFileCmd creates a Command that writes content to path.
+func NewWriteFileCmd(fs afero.Fs, path, content string) Command {
+	return &writeFileCmd{fs: fs, path: path, content: content}
+}
+
+func (c *writeFileCmd) Execute() error {
+	return afero.WriteFile(c.fs, c.path, []byte(c.content), 0o644)
+}
+
+func (c *writeFileCmd) RenderRollback(w io.Writer) error {
+	prior, err := afero.ReadFile(c.fs, c.path)
+	if err != nil {
+		return fmt.Errorf("unable to read current contents of '%s' for rollback: %w", c.path, err)
+	}
+	_, err = fmt.Fprintf(w, "echo '%s' > %s\n", string(prior), c.path)
+	return err
+}
+
+func (c *writeFileCmd) RenderIdempotent(w io.Writer) error {
+	_, err := fmt.Fprintf(w,
+		`if [ "$(cat %s)" != "%s" ]; then
+  echo '%s' > %s
+else
+  echo "%s already set, skipping"
+fi
+`, c.path, c.content, c.content, c.path, c.path)
+	return err
+}
+
+func (c *writeFileCmd) RenderScript(format ScriptFormat, w io.Writer) error {
+	switch format {
+	case Bash:
+		_, err := fmt.Fprintf(w, "echo '%s' > %s\n", c.content, c.path)
+		return err
+	case AnsiblePlaybook:
+		_, err := fmt.Fprintf(w, "    - name: write %s\n      ansible.builtin.copy:\n        dest: %s\n        content: \"%s\"\n\n", c.path, c.path, c.content)
+		return err
+	case SystemdDropIn:
+		_, err := fmt.Fprintf(w, "w %s - - - - %s\n", c.path, c.content)
+		return err
+	case PowerShell:
+		_, err := fmt.Fprintf(w, "Set-Content -Path '%s' -Value '%s'\n", c.path, c.content)
+		return err
+	default:
+		return fmt.Errorf("unknown script format '%s'", format)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileCmdRenderScript(t *testing.T) {
+	tests := []struct {
+		format ScriptFormat
+		want   string
+	}{
+		{Bash, "echo 'performance' > /sys/devices/system/cpu/cpu0/cpufreq/scaling_governor\n"},
+		{AnsiblePlaybook, "    - name: write /sys/devices/system/cpu/cpu0/cpufreq/scaling_governor\n      ansible.builtin.copy:\n        dest: /sys/devices/system/cpu/cpu0/cpufreq/scaling_governor\n        content: \"performance\"\n\n"},
+		{SystemdDropIn, "w /sys/devices/system/cpu/cpu0/cpufreq/scaling_governor - - - - performance\n"},
+		{PowerShell, "Set-Content -Path '/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor' -Value 'performance'\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			cmd := NewWriteFileCmd(afero.NewMemMapFs(), "/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor", "performance")
+			var buf bytes.Buffer
+			require.NoError(t, cmd.RenderScript(tt.format, &buf))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteFileCmdRenderScriptUnknownFormat(t *testing.T) {
+	cmd := NewWriteFileCmd(afero.NewMemMapFs(), "/some/path", "value")
+	var buf bytes.Buffer
+	err := cmd.RenderScript(ScriptFormat(99), &buf)
+	require.Error(t, err)
+}
+
+func TestWriteFileCmdRenderRollback(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := "/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor"
+	require.NoError(t, afero.WriteFile(fs, path, []byte("powersave"), 0o644))
+
+	cmd := NewWriteFileCmd(fs, path, "performance")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderRollback(&buf))
+	require.Equal(t, "echo 'powersave' > "+path+"\n", buf.String())
+}
+
+func TestWriteFileCmdRenderIdempotent(t *testing.T) {
+	cmd := NewWriteFileCmd(afero.NewMemMapFs(), "/some/path", "performance")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderIdempotent(&buf))
+	require.Contains(t, buf.String(), `if [ "$(cat /some/path)" != "performance" ]; then`)
+	require.Contains(t, buf.String(), "already set, skipping")
+}
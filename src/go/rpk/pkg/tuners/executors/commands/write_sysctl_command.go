@@ -0,0 +1,87 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// writeSysctlCmd sets a single kernel parameter via sysctl.
+type writeSysctlCmd struct {
+	fs    afero.Fs
+	key   string
+	value string
+}
+
+// NewWriteSysctlCmd creates a Command that sets the sysctl parameter key to
+// value.
+func NewWriteSysctlCmd(fs afero.Fs, key, value string) Command {
+	return &writeSysctlCmd{fs: fs, key: key, value: value}
+}
+
+func (c *writeSysctlCmd) path() string {
+	return filepath.Join("/proc/sys", strings.ReplaceAll(c.key, ".", "/"))
+}
+
+func (c *writeSysctlCmd) Execute() error {
+	return afero.WriteFile(c.fs, c.path(), []byte(c.value), 0o644)
+}
+
+func (c *writeSysctlCmd) currentValue() (string, error) {
+	content, err := afero.ReadFile(c.fs, c.path())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func (c *writeSysctlCmd) RenderRollback(w io.Writer) error {
+	prior, err := c.currentValue()
+	if err != nil {
+		return fmt.Errorf("unable to read current value of '%s' for rollback: %w", c.key, err)
+	}
+	_, err = fmt.Fprintf(w, "sysctl -w %s=%s\n", c.key, prior)
+	return err
+}
+
+func (c *writeSysctlCmd) RenderIdempotent(w io.Writer) error {
+	_, err := fmt.Fprintf(w,
+		`if [ "$(sysctl -n %s)" != "%s" ]; then
+  sysctl -w %s=%s
+else
+  echo "%s already set to %s, skipping"
+fi
+`, c.key, c.value, c.key, c.value, c.key, c.value)
+	return err
+}
+
+func (c *writeSysctlCmd) RenderScript(format ScriptFormat, w io.Writer) error {
+	switch format {
+	case Bash:
+		_, err := fmt.Fprintf(w, "sysctl -w %s=%s\n", c.key, c.value)
+		return err
+	case AnsiblePlaybook:
+		_, err := fmt.Fprintf(w, "    - name: set sysctl %s\n      ansible.posix.sysctl:\n        name: %s\n        value: \"%s\"\n        sysctl_set: true\n\n", c.key, c.key, c.value)
+		return err
+	case SystemdDropIn:
+		_, err := fmt.Fprintf(w, "%s = %s\n", c.key, c.value)
+		return err
+	case PowerShell:
+		_, err := fmt.Fprintf(w, "# sysctl '%s' has no Windows equivalent, skipping\n", c.key)
+		return err
+	default:
+		return fmt.Errorf("unknown script format '%s'", format)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSysctlCmdRenderScript(t *testing.T) {
+	tests := []struct {
+		format ScriptFormat
+		want   string
+	}{
+		{Bash, "sysctl -w vm.swappiness=1\n"},
+		{AnsiblePlaybook, "    - name: set sysctl vm.swappiness\n      ansible.posix.sysctl:\n        name: vm.swappiness\n        value: \"1\"\n        sysctl_set: true\n\n"},
+		{SystemdDropIn, "vm.swappiness = 1\n"},
+		{PowerShell, "# sysctl 'vm.swappiness' has no Windows equivalent, skipping\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			cmd := NewWriteSysctlCmd(afero.NewMemMapFs(), "vm.swappiness", "1")
+			var buf bytes.Buffer
+			require.NoError(t, cmd.RenderScript(tt.format, &buf))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteSysctlCmdExecuteUsesInjectedFs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cmd := NewWriteSysctlCmd(fs, "vm.swappiness", "1")
+	require.NoError(t, cmd.Execute())
+
+	content, err := afero.ReadFile(fs, "/proc/sys/vm/swappiness")
+	require.NoError(t, err)
+	require.Equal(t, "1", string(content))
+}
+
+func TestWriteSysctlCmdRenderRollback(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/proc/sys/vm/swappiness", []byte("60\n"), 0o644))
+
+	cmd := NewWriteSysctlCmd(fs, "vm.swappiness", "1")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderRollback(&buf))
+	require.Equal(t, "sysctl -w vm.swappiness=60\n", buf.String())
+}
+
+func TestWriteSysctlCmdRenderIdempotent(t *testing.T) {
+	cmd := NewWriteSysctlCmd(afero.NewMemMapFs(), "vm.swappiness", "1")
+	var buf bytes.Buffer
+	require.NoError(t, cmd.RenderIdempotent(&buf))
+	require.Contains(t, buf.String(), `if [ "$(sysctl -n vm.swappiness)" != "1" ]; then`)
+	require.Contains(t, buf.String(), "already set to 1, skipping")
+}
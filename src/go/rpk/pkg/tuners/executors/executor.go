@@ -0,0 +1,25 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import "github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+
+// Executor runs commands.Command values, either directly against the host
+// or by rendering them into a script for later execution.
+type Executor interface {
+	Execute(cmd commands.Command) error
+	// IsLazy reports whether Execute defers the command's effects (e.g. to
+	// a generated script) instead of applying them immediately.
+	IsLazy() bool
+	// Close finalizes any output the Executor has buffered (e.g. flushing
+	// and closing a generated script file) and releases its resources.
+	// Callers must defer Close after constructing an Executor.
+	Close() error
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"testing"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptRenderingExecutorIdempotent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	opts := DefaultScriptRenderingOptions()
+	opts.Idempotent = true
+
+	executor, err := NewScriptRenderingExecutor(fs, "/tune.sh", commands.Bash, opts)
+	require.NoError(t, err)
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/conf", "performance")))
+	require.NoError(t, executor.Close())
+
+	script, err := afero.ReadFile(fs, "/tune.sh")
+	require.NoError(t, err)
+	require.Contains(t, string(script), `if [ "$(cat /etc/conf)" != "performance" ]; then`)
+}
+
+func TestScriptRenderingExecutorNotIdempotent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor, err := NewScriptRenderingExecutor(fs, "/tune.sh", commands.Bash, DefaultScriptRenderingOptions())
+	require.NoError(t, err)
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/conf", "performance")))
+	require.NoError(t, executor.Close())
+
+	script, err := afero.ReadFile(fs, "/tune.sh")
+	require.NoError(t, err)
+	require.NotContains(t, string(script), "if [")
+	require.Contains(t, string(script), "echo 'performance' > /etc/conf")
+}
+
+func TestNewScriptRenderingExecutorRejectsIdempotentWithNonBashFormat(t *testing.T) {
+	opts := DefaultScriptRenderingOptionsForFormat(commands.AnsiblePlaybook)
+	opts.Idempotent = true
+
+	_, err := NewScriptRenderingExecutor(afero.NewMemMapFs(), "/tune.yml", commands.AnsiblePlaybook, opts)
+	require.Error(t, err)
+}
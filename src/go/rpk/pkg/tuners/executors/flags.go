@@ -0,0 +1,48 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+)
+
+// NewExecutorFromFlags builds the Executor described by the `tune` command's
+// --script-format, --script-header-file, and --idempotent flags (formatFlag,
+// headerFileFlag, and idempotent, respectively), writing the generated
+// script to scriptPath. If rollbackPath is non-empty, the returned Executor
+// also emits a paired rollback script there, as with --script-rollback.
+//
+// This is the single place those flags come together into an Executor;
+// callers must defer Close on the result so the generated script(s) are
+// actually flushed to disk.
+func NewExecutorFromFlags(
+	fs afero.Fs, scriptPath, rollbackPath, formatFlag, headerFileFlag string, idempotent bool,
+) (Executor, error) {
+	format, err := commands.ParseScriptFormat(formatFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := DefaultScriptRenderingOptionsForFormat(format)
+	opts.Idempotent = idempotent
+	if headerFileFlag != "" {
+		header, err := LoadHeaderTemplate(fs, headerFileFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts.HeaderTemplate = header
+	}
+
+	if rollbackPath != "" {
+		return NewRollbackScriptRenderingExecutor(fs, scriptPath, rollbackPath, format, opts)
+	}
+	return NewScriptRenderingExecutor(fs, scriptPath, format, opts)
+}
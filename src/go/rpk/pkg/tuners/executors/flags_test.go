@@ -0,0 +1,75 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExecutorFromFlags(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/header.txt", []byte("#!/bin/sh\n# custom header\n\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/etc/conf", []byte("powersave"), 0o644))
+
+	executor, err := NewExecutorFromFlags(fs, "/tune.sh", "/rollback.sh", "ansible", "/header.txt", false)
+	require.NoError(t, err)
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/conf", "performance")))
+	require.NoError(t, executor.Close())
+
+	script, err := afero.ReadFile(fs, "/tune.sh")
+	require.NoError(t, err)
+	require.Contains(t, string(script), "#!/bin/sh\n# custom header")
+	require.Contains(t, string(script), "ansible.builtin.copy")
+
+	exists, err := afero.Exists(fs, "/rollback.sh")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestNewExecutorFromFlagsIdempotent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor, err := NewExecutorFromFlags(fs, "/tune.sh", "", "bash", "", true)
+	require.NoError(t, err)
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/conf", "performance")))
+	require.NoError(t, executor.Close())
+
+	script, err := afero.ReadFile(fs, "/tune.sh")
+	require.NoError(t, err)
+	require.Contains(t, string(script), `if [ "$(cat /etc/conf)" != "performance" ]; then`)
+}
+
+func TestNewExecutorFromFlagsUnknownFormat(t *testing.T) {
+	_, err := NewExecutorFromFlags(afero.NewMemMapFs(), "/tune.sh", "", "unknown", "", false)
+	require.Error(t, err)
+}
+
+func TestNewExecutorFromFlagsAnsibleDefaultHeaderIsAPlay(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor, err := NewExecutorFromFlags(fs, "/tune.yml", "", "ansible", "", false)
+	require.NoError(t, err)
+	require.NoError(t, executor.Execute(commands.NewWriteSysctlCmd(fs, "vm.swappiness", "1")))
+	require.NoError(t, executor.Close())
+
+	script, err := afero.ReadFile(fs, "/tune.yml")
+	require.NoError(t, err)
+	require.False(t, strings.HasPrefix(string(script), "#!/bin/bash"))
+	require.Contains(t, string(script), "---\n- hosts: all\n  become: true\n  tasks:\n")
+	require.Contains(t, string(script), "    - name: set sysctl vm.swappiness\n      ansible.posix.sysctl:\n")
+}
+
+func TestNewExecutorFromFlagsRejectsIdempotentWithNonBashFormat(t *testing.T) {
+	_, err := NewExecutorFromFlags(afero.NewMemMapFs(), "/tune.yml", "", "ansible", "", true)
+	require.Error(t, err)
+}
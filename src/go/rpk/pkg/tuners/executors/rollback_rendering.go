@@ -0,0 +1,89 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+)
+
+// rollbackScriptRenderingExecutor wraps a scriptRenderingExecutor and, for
+// every command it renders, also renders the inverse of that command. The
+// rollback steps are buffered and flushed in reverse order by Close, so the
+// rollback script undoes the tuning script's mutations last-applied-first.
+type rollbackScriptRenderingExecutor struct {
+	*scriptRenderingExecutor
+	rollbackFile afero.File
+	rollbackCmds [][]byte
+}
+
+// NewRollbackScriptRenderingExecutor is like NewScriptRenderingExecutor, but
+// also writes rollbackFilename: a script that reverses every command
+// rendered into filename, so a failed or unwanted tune can be undone.
+func NewRollbackScriptRenderingExecutor(
+	fs afero.Fs, filename, rollbackFilename string, format commands.ScriptFormat, opts ScriptRenderingOptions,
+) (Executor, error) {
+	executor, err := NewScriptRenderingExecutor(fs, filename, format, opts)
+	if err != nil {
+		return nil, err
+	}
+	base := executor.(*scriptRenderingExecutor)
+
+	rollbackOpts := opts
+	rollbackOpts.Preamble = "# Redpanda Tuning Rollback Script\n" +
+		"# ----------------------------------\n" +
+		"# This file was autogenerated by RPK to undo " + filename
+	rollbackFile, err := fs.OpenFile(rollbackFilename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprint(rollbackFile, rollbackOpts.header())
+
+	return &rollbackScriptRenderingExecutor{
+		scriptRenderingExecutor: base,
+		rollbackFile:            rollbackFile,
+	}, nil
+}
+
+func (e *rollbackScriptRenderingExecutor) Execute(cmd commands.Command) error {
+	if err := e.scriptRenderingExecutor.Execute(cmd); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := cmd.RenderRollback(&buf); err != nil {
+		return fmt.Errorf("unable to render rollback for command: %w", err)
+	}
+	e.rollbackCmds = append(e.rollbackCmds, buf.Bytes())
+	return nil
+}
+
+// Close flushes the rollback script, writing each buffered rollback step in
+// reverse order, closes the rollback file, and closes the underlying
+// scriptRenderingExecutor's tuning script file.
+func (e *rollbackScriptRenderingExecutor) Close() error {
+	w := bufio.NewWriter(e.rollbackFile)
+	for i := len(e.rollbackCmds) - 1; i >= 0; i-- {
+		if _, err := w.Write(e.rollbackCmds[i]); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := e.rollbackFile.Close(); err != nil {
+		return err
+	}
+	return e.scriptRenderingExecutor.Close()
+}
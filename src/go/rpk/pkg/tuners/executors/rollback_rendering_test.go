@@ -0,0 +1,42 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackScriptRenderingExecutor(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/etc/first", []byte("old-first"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/etc/second", []byte("old-second"), 0o644))
+
+	executor, err := NewRollbackScriptRenderingExecutor(
+		fs, "/tune.sh", "/rollback.sh", commands.Bash, DefaultScriptRenderingOptions())
+	require.NoError(t, err)
+
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/first", "new-first")))
+	require.NoError(t, executor.Execute(commands.NewWriteFileCmd(fs, "/etc/second", "new-second")))
+	require.NoError(t, executor.Close())
+
+	rollback, err := afero.ReadFile(fs, "/rollback.sh")
+	require.NoError(t, err)
+
+	firstIdx := strings.Index(string(rollback), "echo 'old-first' > /etc/first")
+	secondIdx := strings.Index(string(rollback), "echo 'old-second' > /etc/second")
+	require.NotEqual(t, -1, firstIdx)
+	require.NotEqual(t, -1, secondIdx)
+	require.Greater(t, firstIdx, secondIdx, "rollback steps must be flushed in reverse order")
+}
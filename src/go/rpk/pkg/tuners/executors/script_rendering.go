@@ -13,44 +13,130 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
 	"github.com/spf13/afero"
 )
 
-type scriptRenderingExecutor struct {
-	deferred error
-	writer   *bufio.Writer
+// ScriptRenderingOptions customizes the shebang and header that
+// NewScriptRenderingExecutor writes at the top of the generated script.
+type ScriptRenderingOptions struct {
+	// Shebang is the interpreter line, e.g. "#!/bin/bash" or "#!/bin/sh".
+	Shebang string
+	// Preamble is free-form text placed below the shebang, e.g. a
+	// copyright or license block.
+	Preamble string
+	// StrictMode adds `set -euo pipefail` below the shebang.
+	StrictMode bool
+	// HeaderTemplate, when set, is written verbatim as the script's header
+	// instead of building one from Shebang/Preamble/StrictMode. This is
+	// how --script-header-file plugs in a caller-supplied header.
+	HeaderTemplate string
+	// Idempotent renders every command behind a guard that checks current
+	// state and skips the mutation if it's already applied, so the script
+	// is safe to run more than once (set by the --idempotent flag).
+	Idempotent bool
 }
 
-// FIXME: @david
-// This should also return an error.
-func NewScriptRenderingExecutor(fs afero.Fs, filename string) Executor {
-	file, err := fs.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o755)
+// DefaultScriptRenderingOptions returns rpk's historical header: a bash
+// shebang and an "autogenerated by RPK" banner.
+func DefaultScriptRenderingOptions() ScriptRenderingOptions {
+	return ScriptRenderingOptions{
+		Shebang: "#!/bin/bash",
+		Preamble: "# Redpanda Tuning Script\n" +
+			"# ----------------------------------\n" +
+			"# This file was autogenerated by RPK",
+	}
+}
+
+// DefaultScriptRenderingOptionsForFormat is like DefaultScriptRenderingOptions,
+// but tailors the header to format instead of assuming Bash: formats with no
+// shebang of their own render without one, and AnsiblePlaybook's tasks need
+// a play (hosts/become/tasks) to live under, which this adds to the header
+// so the rendered tasks just need to nest under "tasks:".
+func DefaultScriptRenderingOptionsForFormat(format commands.ScriptFormat) ScriptRenderingOptions {
+	opts := ScriptRenderingOptions{
+		Preamble: "# Redpanda Tuning Script\n" +
+			"# ----------------------------------\n" +
+			"# This file was autogenerated by RPK",
+	}
+	switch format {
+	case commands.Bash:
+		opts.Shebang = "#!/bin/bash"
+	case commands.AnsiblePlaybook:
+		opts.Preamble += "\n\n---\n- hosts: all\n  become: true\n  tasks:"
+	}
+	return opts
+}
+
+// LoadHeaderTemplate reads the contents of path (as passed to
+// --script-header-file) to use as a ScriptRenderingOptions.HeaderTemplate.
+func LoadHeaderTemplate(fs afero.Fs, path string) (string, error) {
+	content, err := afero.ReadFile(fs, path)
 	if err != nil {
-		return &scriptRenderingExecutor{
-			deferred: err,
-			writer:   nil,
-		}
+		return "", fmt.Errorf("unable to read script header file '%s': %w", path, err)
+	}
+	return string(content), nil
+}
+
+func (o ScriptRenderingOptions) header() string {
+	if o.HeaderTemplate != "" {
+		return o.HeaderTemplate
+	}
+	var b strings.Builder
+	if o.Shebang != "" {
+		b.WriteString(o.Shebang)
+		b.WriteString("\n\n")
+	}
+	if o.StrictMode {
+		b.WriteString("set -euo pipefail\n\n")
 	}
-	header := `#!/bin/bash
+	b.WriteString(o.Preamble)
+	b.WriteString("\n\n")
+	return b.String()
+}
 
-# Redpanda Tuning Script
-# ----------------------------------
-# This file was autogenerated by RPK
+type scriptRenderingExecutor struct {
+	file       afero.File
+	writer     *bufio.Writer
+	format     commands.ScriptFormat
+	idempotent bool
+}
 
-`
+// NewScriptRenderingExecutor creates an Executor that renders every command
+// it's given, in the given format, to filename, preceded by the header
+// described by opts.
+func NewScriptRenderingExecutor(
+	fs afero.Fs, filename string, format commands.ScriptFormat, opts ScriptRenderingOptions,
+) (Executor, error) {
+	if opts.Idempotent && format != commands.Bash {
+		return nil, fmt.Errorf("--idempotent is only supported with --script-format=bash, got %s", format)
+	}
+	file, err := fs.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o755)
+	if err != nil {
+		return nil, err
+	}
 	w := bufio.NewWriter(file)
-	_, _ = fmt.Fprint(w, header)
-	_ = w.Flush()
-	return &scriptRenderingExecutor{
-		deferred: nil,
-		writer:   w,
+	_, _ = fmt.Fprint(w, opts.header())
+	if err := w.Flush(); err != nil {
+		return nil, err
 	}
+	return &scriptRenderingExecutor{
+		file:       file,
+		writer:     w,
+		format:     format,
+		idempotent: opts.Idempotent,
+	}, nil
 }
 
 func (e *scriptRenderingExecutor) Execute(cmd commands.Command) error {
-	err := cmd.RenderScript(e.writer)
+	var err error
+	if e.idempotent {
+		err = cmd.RenderIdempotent(e.writer)
+	} else {
+		err = cmd.RenderScript(e.format, e.writer)
+	}
 	if err != nil {
 		return err
 	}
@@ -60,3 +146,11 @@ func (e *scriptRenderingExecutor) Execute(cmd commands.Command) error {
 func (*scriptRenderingExecutor) IsLazy() bool {
 	return true
 }
+
+// Close flushes any buffered output and closes the underlying script file.
+func (e *scriptRenderingExecutor) Close() error {
+	if err := e.writer.Flush(); err != nil {
+		return err
+	}
+	return e.file.Close()
+}
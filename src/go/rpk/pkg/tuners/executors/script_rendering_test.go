@@ -0,0 +1,60 @@
+// Copyright 2020 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package executors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/tuners/executors/commands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptRenderingOptionsHeader(t *testing.T) {
+	opts := DefaultScriptRenderingOptions()
+	require.Equal(t, "#!/bin/bash\n\n# Redpanda Tuning Script\n"+
+		"# ----------------------------------\n# This file was autogenerated by RPK\n\n", opts.header())
+
+	opts.StrictMode = true
+	require.Contains(t, opts.header(), "set -euo pipefail\n\n")
+
+	opts.HeaderTemplate = "#!/bin/sh\n# custom header\n\n"
+	require.Equal(t, opts.HeaderTemplate, opts.header())
+}
+
+func TestLoadHeaderTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/header.txt", []byte("#!/bin/sh\n# dual-licensed header\n\n"), 0o644))
+
+	header, err := LoadHeaderTemplate(fs, "/header.txt")
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/sh\n# dual-licensed header\n\n", header)
+}
+
+func TestLoadHeaderTemplateMissingFile(t *testing.T) {
+	_, err := LoadHeaderTemplate(afero.NewMemMapFs(), "/missing.txt")
+	require.Error(t, err)
+}
+
+func TestDefaultScriptRenderingOptionsForFormat(t *testing.T) {
+	bash := DefaultScriptRenderingOptionsForFormat(commands.Bash)
+	require.Equal(t, "#!/bin/bash", bash.Shebang)
+	require.True(t, strings.HasPrefix(bash.header(), "#!/bin/bash\n\n"))
+
+	ansible := DefaultScriptRenderingOptionsForFormat(commands.AnsiblePlaybook)
+	require.Empty(t, ansible.Shebang)
+	require.False(t, strings.HasPrefix(ansible.header(), "#!"))
+	require.Contains(t, ansible.header(), "---\n- hosts: all\n  become: true\n  tasks:\n")
+
+	systemd := DefaultScriptRenderingOptionsForFormat(commands.SystemdDropIn)
+	require.Empty(t, systemd.Shebang)
+	require.False(t, strings.HasPrefix(systemd.header(), "#!"))
+}